@@ -10,15 +10,26 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/jitenkr2030/Brain-AI-Framework/sdk/go/vectorindex"
 )
 
 // Enums
@@ -104,6 +115,33 @@ type BrainAIConfig struct {
 	LearningRate        float64 `json:"learningRate"`
 	SimilarityThreshold float64 `json:"similarityThreshold"`
 	MaxReasoningDepth   int     `json:"maxReasoningDepth"`
+
+	// LocalMode runs vector storage and search against an in-process HNSW
+	// index instead of the remote Brain AI server. VectorDimensions is the
+	// size of the vectors that will be stored; when it is zero, the index is
+	// created with the size of the first vector StoreVector stores.
+	LocalMode        bool `json:"localMode"`
+	VectorDimensions int  `json:"vectorDimensions"`
+
+	// Embedder, when set, makes StoreMemory/SearchMemories embed textual
+	// content locally and route it through StoreVector/SearchSimilarVectors
+	// instead of the plain memory API.
+	Embedder Embedder `json:"-"`
+
+	// RetryPolicy governs how makeRequest retries retryable failures and
+	// trips the per-endpoint circuit breaker. The zero value is replaced
+	// with DefaultRetryPolicy() by NewBrainAISDK.
+	RetryPolicy RetryPolicy `json:"-"`
+}
+
+// Embedder turns text into a vector so it can be stored and searched by
+// similarity without relying on the Brain AI server to embed it.
+// Implementations live in subpackages, e.g. embedder/hashembed for a
+// deterministic stand-in and embedder/httpembed for an OpenAI-compatible
+// HTTP backend.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float64, error)
+	Dimensions() int
 }
 
 type BrainAISDK struct {
@@ -111,6 +149,36 @@ type BrainAISDK struct {
 	client  *http.Client
 	mu      sync.RWMutex
 	baseURL string
+
+	localIndex *vectorindex.Index
+	localSeq   uint64
+
+	// closeCh is closed by Close to abort every in-flight request sharing
+	// this SDK instance, mirroring the cancel-channel pattern netstack's
+	// gonet adapter uses to implement per-conn deadlines.
+	closeCh       chan struct{}
+	closeOnce     sync.Once
+	readDeadline  time.Time
+	writeDeadline time.Time
+
+	embedCacheMu sync.RWMutex
+	embedCache   map[string][]float64
+
+	requestsTotal uint64
+	retriesTotal  uint64
+	breakersMu    sync.Mutex
+	breakers      map[string]*circuitBreaker
+
+	// limiter throttles outgoing attempts in makeRequest when set (e.g. by
+	// ClientFactory for a tenant with a configured rate limit). nil means
+	// unlimited.
+	limiter *rate.Limiter
+
+	// onRequest, if set, is called with the updated requestsTotal after
+	// every attempt makeRequest counts. ClientFactory uses this to track
+	// and enforce per-tenant quotas without BrainAISDK knowing about
+	// tenants itself.
+	onRequest func(total uint64)
 }
 
 // NewBrainAISDK creates a new Brain AI SDK instance
@@ -126,6 +194,8 @@ func NewBrainAISDK(config BrainAIConfig) *BrainAISDK {
 		client: &http.Client{
 			Timeout: time.Duration(30000) * time.Millisecond,
 		},
+		closeCh:  make(chan struct{}),
+		breakers: make(map[string]*circuitBreaker),
 	}
 	
 	// Update with provided config
@@ -155,63 +225,216 @@ func NewBrainAISDK(config BrainAIConfig) *BrainAISDK {
 	if config.MaxReasoningDepth != 0 {
 		sdk.config.MaxReasoningDepth = config.MaxReasoningDepth
 	}
+	sdk.config.LocalMode = config.LocalMode
+	sdk.config.VectorDimensions = config.VectorDimensions
+	sdk.config.Embedder = config.Embedder
+
+	sdk.config.RetryPolicy = config.RetryPolicy
+	if sdk.config.RetryPolicy.MaxAttempts == 0 {
+		sdk.config.RetryPolicy = DefaultRetryPolicy()
+	}
+
+	if sdk.config.LocalMode && sdk.config.VectorDimensions > 0 {
+		sdk.localIndex = vectorindex.New(sdk.config.VectorDimensions, vectorindex.Cosine)
+	}
 
 	return sdk
 }
 
-// makeRequest performs HTTP request
-func (sdk *BrainAISDK) makeRequest(endpoint, method string, data interface{}) (map[string]interface{}, error) {
+// withDeadlines derives a request context from ctx that is also cancelled
+// when the SDK-wide read/write deadlines elapse or Close is called, so a
+// single Close aborts every in-flight request sharing this SDK instance.
+func (sdk *BrainAISDK) withDeadlines(ctx context.Context) (context.Context, context.CancelFunc) {
+	sdk.mu.RLock()
+	rd, wd := sdk.readDeadline, sdk.writeDeadline
+	closeCh := sdk.closeCh
+	sdk.mu.RUnlock()
+
+	deadline := rd
+	if !wd.IsZero() && (deadline.IsZero() || wd.Before(deadline)) {
+		deadline = wd
+	}
+
+	var cancel context.CancelFunc
+	if !deadline.IsZero() {
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	select {
+	case <-closeCh:
+		cancel()
+	default:
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-closeCh:
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		cancel()
+	}
+}
+
+// SetReadDeadline sets a deadline for requests issued by this SDK. Each
+// request attempt is cancelled at the earlier of the read and write
+// deadlines, whether it is still sending or already waiting on a response.
+// The deadlines are read when an attempt starts; attempts already in flight
+// keep the deadline they started with.
+func (sdk *BrainAISDK) SetReadDeadline(t time.Time) {
+	sdk.mu.Lock()
+	sdk.readDeadline = t
+	sdk.mu.Unlock()
+}
+
+// SetWriteDeadline sets the write deadline, which is combined with the read
+// deadline as described on SetReadDeadline.
+func (sdk *BrainAISDK) SetWriteDeadline(t time.Time) {
+	sdk.mu.Lock()
+	sdk.writeDeadline = t
+	sdk.mu.Unlock()
+}
+
+// Close aborts every in-flight request to the server issued by this SDK
+// instance, including calls waiting to retry, and requests started after
+// Close fail. Operations served by the local index are not affected. Close
+// is safe to call more than once and from multiple goroutines.
+func (sdk *BrainAISDK) Close() error {
+	sdk.closeOnce.Do(func() {
+		close(sdk.closeCh)
+	})
+	return nil
+}
+
+// makeRequest performs an HTTP request, honoring ctx cancellation as well
+// as the SDK's own deadlines and Close. It retries retryable failures per
+// sdk.config.RetryPolicy and fails fast with ErrCircuitOpen while the
+// endpoint's circuit breaker is open.
+func (sdk *BrainAISDK) makeRequest(ctx context.Context, endpoint, method string, data interface{}) (map[string]interface{}, error) {
+	policy := sdk.config.RetryPolicy
+	breaker := sdk.breakerFor(endpoint)
+
+	var lastErr error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		// Wait on the limiter before asking the breaker, so a failed wait
+		// never leaves a half-open probe admitted but unrecorded.
+		if sdk.limiter != nil {
+			if err := sdk.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if !breaker.Allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		total := atomic.AddUint64(&sdk.requestsTotal, 1)
+		if sdk.onRequest != nil {
+			sdk.onRequest(total)
+		}
+		result, statusCode, retryAfter, err := sdk.doRequest(ctx, endpoint, method, data)
+		if err == nil {
+			breaker.RecordSuccess()
+			return result, nil
+		}
+		breaker.RecordFailure()
+		lastErr = err
+
+		retryOn := policy.RetryOn
+		if retryOn == nil {
+			retryOn = defaultRetryOn
+		}
+		if attempt == policy.MaxAttempts-1 || !retryOn(statusCode, err) {
+			return nil, err
+		}
+
+		atomic.AddUint64(&sdk.retriesTotal, 1)
+		delay := retryAfter
+		if delay == 0 {
+			delay = retryBackoff(policy, attempt)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-sdk.closeCh:
+			return nil, err
+		}
+	}
+
+	return nil, lastErr
+}
+
+// doRequest performs a single HTTP attempt, returning the parsed response,
+// its status code (0 if the request never reached the server), and any
+// Retry-After duration the server asked for.
+func (sdk *BrainAISDK) doRequest(ctx context.Context, endpoint, method string, data interface{}) (map[string]interface{}, int, time.Duration, error) {
 	url := sdk.baseURL + endpoint
-	
+
+	reqCtx, cancel := sdk.withDeadlines(ctx)
+	defer cancel()
+
 	var req *http.Request
 	var err error
-	
+
 	if data != nil {
 		jsonData, err := json.Marshal(data)
 		if err != nil {
-			return nil, err
+			return nil, 0, 0, err
 		}
-		req, err = http.NewRequest(method, url, bytes.NewBuffer(jsonData))
+		req, err = http.NewRequestWithContext(reqCtx, method, url, bytes.NewBuffer(jsonData))
 	} else {
-		req, err = http.NewRequest(method, url, nil)
+		req, err = http.NewRequestWithContext(reqCtx, method, url, nil)
 	}
-	
+
 	if err != nil {
-		return nil, err
+		return nil, 0, 0, err
 	}
-	
+
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	if sdk.config.APIKey != "" {
 		req.Header.Set("Authorization", "Bearer "+sdk.config.APIKey)
 	}
-	
+
 	resp, err := sdk.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, 0, err
 	}
 	defer resp.Body.Close()
-	
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, resp.StatusCode, 0, err
 	}
-	
+
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP error! status: %d", resp.StatusCode)
+		return nil, resp.StatusCode, retryAfterDuration(resp.Header.Get("Retry-After")), fmt.Errorf("HTTP error! status: %d", resp.StatusCode)
 	}
-	
+
 	var result map[string]interface{}
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, err
+		return nil, resp.StatusCode, 0, err
 	}
-	
-	return result, nil
+
+	return result, resp.StatusCode, 0, nil
 }
 
 // StoreMemory stores a memory node in the brain
-func (sdk *BrainAISDK) StoreMemory(content interface{}, memoryType MemoryType, metadata map[string]interface{}) (string, error) {
+func (sdk *BrainAISDK) StoreMemory(ctx context.Context, content interface{}, memoryType MemoryType, metadata map[string]interface{}) (string, error) {
+	if sdk.config.Embedder != nil {
+		return sdk.storeMemoryEmbedded(ctx, content, memoryType, metadata)
+	}
+
 	memoryNode := MemoryNode{
 		Content:     content,
 		Type:        memoryType,
@@ -220,43 +443,47 @@ func (sdk *BrainAISDK) StoreMemory(content interface{}, memoryType MemoryType, m
 		Connections: []string{},
 		Metadata:    metadata,
 	}
-	
-	result, err := sdk.makeRequest("/api/memory", "POST", memoryNode)
+
+	result, err := sdk.makeRequest(ctx, "/api/memory", "POST", memoryNode)
 	if err != nil {
 		return "", err
 	}
-	
+
 	if id, ok := result["id"].(string); ok {
 		return id, nil
 	}
-	
+
 	return "", fmt.Errorf("invalid response: missing id")
 }
 
 // GetMemory retrieves memory by ID
-func (sdk *BrainAISDK) GetMemory(id string) (*MemoryNode, error) {
-	result, err := sdk.makeRequest("/api/memory/"+id, "GET", nil)
+func (sdk *BrainAISDK) GetMemory(ctx context.Context, id string) (*MemoryNode, error) {
+	result, err := sdk.makeRequest(ctx, "/api/memory/"+id, "GET", nil)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	memoryNode, err := parseMemoryNode(result)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	return memoryNode, nil
 }
 
 // SearchMemories searches memories by content similarity
-func (sdk *BrainAISDK) SearchMemories(query interface{}, limit int) ([]SearchResult, error) {
+func (sdk *BrainAISDK) SearchMemories(ctx context.Context, query interface{}, limit int) ([]SearchResult, error) {
+	if sdk.config.Embedder != nil {
+		return sdk.searchMemoriesEmbedded(ctx, query, limit)
+	}
+
 	request := map[string]interface{}{
 		"query":     query,
 		"limit":     limit,
 		"threshold": sdk.config.SimilarityThreshold,
 	}
-	
-	result, err := sdk.makeRequest("/api/memory/search", "POST", request)
+
+	result, err := sdk.makeRequest(ctx, "/api/memory/search", "POST", request)
 	if err != nil {
 		return nil, err
 	}
@@ -282,43 +509,116 @@ func (sdk *BrainAISDK) SearchMemories(query interface{}, limit int) ([]SearchRes
 	return searchResults, nil
 }
 
+func (sdk *BrainAISDK) storeMemoryEmbedded(ctx context.Context, content interface{}, memoryType MemoryType, metadata map[string]interface{}) (string, error) {
+	text, ok := content.(string)
+	if !ok {
+		return "", fmt.Errorf("brain-ai: Embedder requires string memory content, got %T", content)
+	}
+
+	vector, err := sdk.embed(ctx, text)
+	if err != nil {
+		return "", err
+	}
+
+	vectorMetadata := make(map[string]interface{}, len(metadata)+2)
+	for k, v := range metadata {
+		vectorMetadata[k] = v
+	}
+	vectorMetadata["content"] = text
+	vectorMetadata["memoryType"] = int(memoryType)
+
+	return sdk.StoreVector(ctx, vector, vectorMetadata)
+}
+
+func (sdk *BrainAISDK) searchMemoriesEmbedded(ctx context.Context, query interface{}, limit int) ([]SearchResult, error) {
+	text, ok := query.(string)
+	if !ok {
+		return nil, fmt.Errorf("brain-ai: Embedder requires a string query, got %T", query)
+	}
+
+	vector, err := sdk.embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := sdk.SearchSimilarVectors(ctx, vector, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		if content, ok := results[i].Metadata["content"]; ok {
+			results[i].Content = content
+		}
+	}
+	return results, nil
+}
+
+// embed runs sdk.config.Embedder, caching results by the SHA-256 of text
+// so repeated memories/queries don't pay for redundant embedding calls.
+func (sdk *BrainAISDK) embed(ctx context.Context, text string) ([]float64, error) {
+	key := sha256.Sum256([]byte(text))
+	cacheKey := hex.EncodeToString(key[:])
+
+	sdk.embedCacheMu.RLock()
+	if vector, ok := sdk.embedCache[cacheKey]; ok {
+		sdk.embedCacheMu.RUnlock()
+		return vector, nil
+	}
+	sdk.embedCacheMu.RUnlock()
+
+	vector, err := sdk.config.Embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	sdk.embedCacheMu.Lock()
+	if sdk.embedCache == nil {
+		sdk.embedCache = make(map[string][]float64)
+	}
+	sdk.embedCache[cacheKey] = vector
+	sdk.embedCacheMu.Unlock()
+
+	return vector, nil
+}
+
 // ConnectMemories connects two memories
-func (sdk *BrainAISDK) ConnectMemories(memoryID1, memoryID2 string, strength float64) error {
+func (sdk *BrainAISDK) ConnectMemories(ctx context.Context, memoryID1, memoryID2 string, strength float64) error {
 	request := map[string]interface{}{
 		"memoryId1": memoryID1,
 		"memoryId2": memoryID2,
 		"strength":  strength,
 	}
-	
-	_, err := sdk.makeRequest("/api/memory/connect", "POST", request)
+
+	_, err := sdk.makeRequest(ctx, "/api/memory/connect", "POST", request)
 	return err
 }
 
 // UpdateMemoryStrength updates memory strength
-func (sdk *BrainAISDK) UpdateMemoryStrength(id string, delta float64) error {
+func (sdk *BrainAISDK) UpdateMemoryStrength(ctx context.Context, id string, delta float64) error {
 	request := map[string]interface{}{
 		"delta": delta,
 	}
-	
-	_, err := sdk.makeRequest("/api/memory/"+id+"/strength", "PATCH", request)
+
+	_, err := sdk.makeRequest(ctx, "/api/memory/"+id+"/strength", "PATCH", request)
 	return err
 }
 
 // Learn learns from experience
-func (sdk *BrainAISDK) Learn(pattern string, context []string) error {
+func (sdk *BrainAISDK) Learn(ctx context.Context, pattern string, contextList []string) error {
 	request := map[string]interface{}{
 		"pattern": pattern,
-		"context": context,
+		"context": contextList,
 		"rate":    sdk.config.LearningRate,
 	}
-	
-	_, err := sdk.makeRequest("/api/learning/learn", "POST", request)
+
+	_, err := sdk.makeRequest(ctx, "/api/learning/learn", "POST", request)
 	return err
 }
 
 // GetLearningPatterns gets learning patterns
-func (sdk *BrainAISDK) GetLearningPatterns() ([]LearningPattern, error) {
-	result, err := sdk.makeRequest("/api/learning/patterns", "GET", nil)
+func (sdk *BrainAISDK) GetLearningPatterns(ctx context.Context) ([]LearningPattern, error) {
+	result, err := sdk.makeRequest(ctx, "/api/learning/patterns", "GET", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -346,73 +646,202 @@ func (sdk *BrainAISDK) GetLearningPatterns() ([]LearningPattern, error) {
 }
 
 // Reason performs reasoning on a query
-func (sdk *BrainAISDK) Reason(query string, context []string) (ReasoningResult, error) {
+func (sdk *BrainAISDK) Reason(ctx context.Context, query string, contextList []string) (ReasoningResult, error) {
 	request := map[string]interface{}{
-		"query":     query,
-		"context":   context,
-		"maxDepth":  sdk.config.MaxReasoningDepth,
+		"query":    query,
+		"context":  contextList,
+		"maxDepth": sdk.config.MaxReasoningDepth,
 	}
-	
-	result, err := sdk.makeRequest("/api/reasoning/reason", "POST", request)
+
+	result, err := sdk.makeRequest(ctx, "/api/reasoning/reason", "POST", request)
 	if err != nil {
 		return ReasoningResult{}, err
 	}
-	
+
 	reasoningResult := parseReasoningResult(result)
 	return reasoningResult, nil
 }
 
+// ReasoningStep is one intermediate step emitted by ReasonStream as the
+// backend works towards a conclusion.
+type ReasoningStep struct {
+	StepIndex         int      `json:"step_index"`
+	PartialConclusion string   `json:"partial_conclusion"`
+	Confidence        float64  `json:"confidence"`
+	Evidence          []string `json:"evidence"`
+	Final             bool     `json:"final"`
+}
+
+// ReasonStream performs reasoning on a query like Reason, but streams back
+// intermediate steps as the backend produces them instead of blocking
+// until the full result is ready. The returned channels are closed once
+// the stream ends, ctx is cancelled, or an error occurs; at most one error
+// is ever sent on the error channel.
+func (sdk *BrainAISDK) ReasonStream(ctx context.Context, query string, contextList []string) (<-chan ReasoningStep, <-chan error) {
+	steps := make(chan ReasoningStep)
+	errs := make(chan error, 1)
+
+	reqCtx, cancel := sdk.withDeadlines(ctx)
+
+	go func() {
+		defer cancel()
+		defer close(steps)
+		defer close(errs)
+
+		request := map[string]interface{}{
+			"query":    query,
+			"context":  contextList,
+			"maxDepth": sdk.config.MaxReasoningDepth,
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		url := sdk.baseURL + "/api/reasoning/reason?stream=1"
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			errs <- err
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "text/event-stream")
+		if sdk.config.APIKey != "" {
+			req.Header.Set("Authorization", "Bearer "+sdk.config.APIKey)
+		}
+
+		resp, err := sdk.client.Do(req)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			errs <- fmt.Errorf("HTTP error! status: %d", resp.StatusCode)
+			return
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			line = strings.TrimPrefix(line, "data:")
+			line = strings.TrimSpace(line)
+			if line == "" || line == "[DONE]" {
+				continue
+			}
+
+			var step ReasoningStep
+			if err := json.Unmarshal([]byte(line), &step); err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case steps <- step:
+			case <-reqCtx.Done():
+				return
+			}
+
+			if step.Final {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return steps, errs
+}
+
+// CollectReasoning drains the channels returned by ReasonStream into the
+// same ReasoningResult shape returned by Reason, for callers that don't
+// need intermediate steps.
+func CollectReasoning(steps <-chan ReasoningStep, errs <-chan error) (ReasoningResult, error) {
+	var result ReasoningResult
+
+	for step := range steps {
+		result.ReasoningPath = append(result.ReasoningPath, step.PartialConclusion)
+		result.SupportingEvidence = append(result.SupportingEvidence, step.Evidence...)
+		result.Conclusion = step.PartialConclusion
+		result.Confidence = step.Confidence
+		result.Timestamp = time.Now().UnixMilli()
+	}
+
+	if err := <-errs; err != nil {
+		return ReasoningResult{}, err
+	}
+
+	return result, nil
+}
+
 // AddFeedback adds feedback for learning
-func (sdk *BrainAISDK) AddFeedback(feedbackType FeedbackType, information, reasoning string) error {
+func (sdk *BrainAISDK) AddFeedback(ctx context.Context, feedbackType FeedbackType, information, reasoning string) error {
 	request := map[string]interface{}{
-		"type":       getFeedbackTypeString(feedbackType),
+		"type":        getFeedbackTypeString(feedbackType),
 		"information": information,
 		"reasoning":   reasoning,
 		"timestamp":   time.Now().UnixMilli(),
 	}
-	
-	_, err := sdk.makeRequest("/api/feedback", "POST", request)
+
+	_, err := sdk.makeRequest(ctx, "/api/feedback", "POST", request)
 	return err
 }
 
-// StoreVector stores vector for similarity search
-func (sdk *BrainAISDK) StoreVector(vector []float64, metadata map[string]interface{}) (string, error) {
+// StoreVector stores vector for similarity search. When the SDK is running
+// in LocalMode, the vector is inserted into the in-process HNSW index
+// instead of being sent to the remote server.
+func (sdk *BrainAISDK) StoreVector(ctx context.Context, vector []float64, metadata map[string]interface{}) (string, error) {
+	if sdk.config.LocalMode {
+		return sdk.storeVectorLocal(vector, metadata)
+	}
+
 	vectorEntry := VectorEntry{
 		Vector:     vector,
 		Metadata:   metadata,
 		Timestamp:  time.Now().UnixMilli(),
 	}
-	
-	result, err := sdk.makeRequest("/api/vector", "POST", vectorEntry)
+
+	result, err := sdk.makeRequest(ctx, "/api/vector", "POST", vectorEntry)
 	if err != nil {
 		return "", err
 	}
-	
+
 	if id, ok := result["id"].(string); ok {
 		return id, nil
 	}
-	
+
 	return "", fmt.Errorf("invalid response: missing id")
 }
 
-// SearchSimilarVectors searches for similar vectors
-func (sdk *BrainAISDK) SearchSimilarVectors(vector []float64, limit int) ([]SearchResult, error) {
+// SearchSimilarVectors searches for similar vectors. When the SDK is
+// running in LocalMode, the search runs against the in-process HNSW index.
+func (sdk *BrainAISDK) SearchSimilarVectors(ctx context.Context, vector []float64, limit int) ([]SearchResult, error) {
+	if sdk.config.LocalMode {
+		return sdk.SearchVectorsWithFilter(vector, limit, nil)
+	}
+
 	request := map[string]interface{}{
 		"vector":     vector,
 		"limit":      limit,
 		"threshold":  sdk.config.SimilarityThreshold,
 	}
-	
-	result, err := sdk.makeRequest("/api/vector/search", "POST", request)
+
+	result, err := sdk.makeRequest(ctx, "/api/vector/search", "POST", request)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	results, ok := result["results"].([]interface{})
 	if !ok {
 		return []SearchResult{}, nil
 	}
-	
+
 	searchResults := make([]SearchResult, 0, len(results))
 	for _, r := range results {
 		if rMap, ok := r.(map[string]interface{}); ok {
@@ -425,12 +854,82 @@ func (sdk *BrainAISDK) SearchSimilarVectors(vector []float64, limit int) ([]Sear
 			searchResults = append(searchResults, searchResult)
 		}
 	}
-	
+
+	return searchResults, nil
+}
+
+// SearchVectorsWithFilter runs a hybrid metadata+vector query against the
+// local HNSW index, keeping only results whose metadata satisfies filter.
+// It requires LocalMode to be enabled.
+func (sdk *BrainAISDK) SearchVectorsWithFilter(vector []float64, limit int, filter func(metadata map[string]interface{}) bool) ([]SearchResult, error) {
+	sdk.mu.Lock()
+	idx := sdk.localIndex
+	sdk.mu.Unlock()
+	if !sdk.config.LocalMode || idx == nil {
+		return nil, fmt.Errorf("brain-ai: SearchVectorsWithFilter requires LocalMode with VectorDimensions set")
+	}
+
+	matches, err := idx.SearchWithFilter(vector, limit, vectorindex.Filter(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	searchResults := make([]SearchResult, 0, len(matches))
+	for _, m := range matches {
+		searchResults = append(searchResults, SearchResult{
+			ID:       m.ID,
+			Score:    m.Score,
+			Metadata: m.Metadata,
+		})
+	}
 	return searchResults, nil
 }
 
+func (sdk *BrainAISDK) storeVectorLocal(vector []float64, metadata map[string]interface{}) (string, error) {
+	sdk.mu.Lock()
+	idx := sdk.localIndex
+	if idx == nil {
+		if len(vector) == 0 {
+			sdk.mu.Unlock()
+			return "", fmt.Errorf("brain-ai: cannot infer VectorDimensions from an empty vector")
+		}
+		sdk.config.VectorDimensions = len(vector)
+		idx = vectorindex.New(len(vector), vectorindex.Cosine)
+		sdk.localIndex = idx
+	}
+	sdk.mu.Unlock()
+
+	id := fmt.Sprintf("local-%d-%d", time.Now().UnixNano(), atomic.AddUint64(&sdk.localSeq, 1))
+	if err := idx.Insert(id, vector, metadata); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// SaveLocalIndex snapshots the in-process HNSW index to path. It requires
+// LocalMode to be enabled.
+func (sdk *BrainAISDK) SaveLocalIndex(path string) error {
+	if sdk.localIndex == nil {
+		return fmt.Errorf("brain-ai: SaveLocalIndex requires LocalMode with a populated index")
+	}
+	return sdk.localIndex.Save(path)
+}
+
+// LoadLocalIndex replaces the in-process HNSW index with one previously
+// written by SaveLocalIndex.
+func (sdk *BrainAISDK) LoadLocalIndex(path string) error {
+	idx, err := vectorindex.Load(path)
+	if err != nil {
+		return err
+	}
+	sdk.mu.Lock()
+	sdk.localIndex = idx
+	sdk.mu.Unlock()
+	return nil
+}
+
 // CreateGraphNode creates or updates graph node
-func (sdk *BrainAISDK) CreateGraphNode(id, label, nodeType string, properties map[string]interface{}) error {
+func (sdk *BrainAISDK) CreateGraphNode(ctx context.Context, id, label, nodeType string, properties map[string]interface{}) error {
 	node := GraphNode{
 		ID:          id,
 		Label:       label,
@@ -439,30 +938,30 @@ func (sdk *BrainAISDK) CreateGraphNode(id, label, nodeType string, properties ma
 		Connections: []string{},
 		Weight:      1.0,
 	}
-	
-	_, err := sdk.makeRequest("/api/graph/node", "POST", node)
+
+	_, err := sdk.makeRequest(ctx, "/api/graph/node", "POST", node)
 	return err
 }
 
 // ConnectGraphNodes connects graph nodes
-func (sdk *BrainAISDK) ConnectGraphNodes(nodeID1, nodeID2 string, weight float64) error {
+func (sdk *BrainAISDK) ConnectGraphNodes(ctx context.Context, nodeID1, nodeID2 string, weight float64) error {
 	request := map[string]interface{}{
 		"nodeId1": nodeID1,
 		"nodeId2": nodeID2,
 		"weight":  weight,
 	}
-	
-	_, err := sdk.makeRequest("/api/graph/connect", "POST", request)
+
+	_, err := sdk.makeRequest(ctx, "/api/graph/connect", "POST", request)
 	return err
 }
 
 // GetGraphNeighbors gets graph neighbors
-func (sdk *BrainAISDK) GetGraphNeighbors(nodeID string, depth int) ([]GraphNode, error) {
+func (sdk *BrainAISDK) GetGraphNeighbors(ctx context.Context, nodeID string, depth int) ([]GraphNode, error) {
 	request := map[string]interface{}{
 		"depth": depth,
 	}
-	
-	result, err := sdk.makeRequest("/api/graph/neighbors/"+nodeID, "POST", request)
+
+	result, err := sdk.makeRequest(ctx, "/api/graph/neighbors/"+nodeID, "POST", request)
 	if err != nil {
 		return nil, err
 	}
@@ -491,8 +990,8 @@ func (sdk *BrainAISDK) GetGraphNeighbors(nodeID string, depth int) ([]GraphNode,
 }
 
 // GetStatus gets system status
-func (sdk *BrainAISDK) GetStatus() (map[string]interface{}, error) {
-	result, err := sdk.makeRequest("/api/status", "GET", nil)
+func (sdk *BrainAISDK) GetStatus(ctx context.Context) (map[string]interface{}, error) {
+	result, err := sdk.makeRequest(ctx, "/api/status", "GET", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -500,8 +999,8 @@ func (sdk *BrainAISDK) GetStatus() (map[string]interface{}, error) {
 }
 
 // GetStatistics gets system statistics
-func (sdk *BrainAISDK) GetStatistics() (map[string]interface{}, error) {
-	result, err := sdk.makeRequest("/api/stats", "GET", nil)
+func (sdk *BrainAISDK) GetStatistics(ctx context.Context) (map[string]interface{}, error) {
+	result, err := sdk.makeRequest(ctx, "/api/stats", "GET", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -509,49 +1008,80 @@ func (sdk *BrainAISDK) GetStatistics() (map[string]interface{}, error) {
 }
 
 // ClearAll clears all data
-func (sdk *BrainAISDK) ClearAll() error {
-	_, err := sdk.makeRequest("/api/clear", "POST", nil)
+func (sdk *BrainAISDK) ClearAll(ctx context.Context) error {
+	_, err := sdk.makeRequest(ctx, "/api/clear", "POST", nil)
 	return err
 }
 
-// Batch performs batch operations
-func (sdk *BrainAISDK) Batch(operations []BatchOperation) ([]map[string]interface{}, error) {
+// BatchResult is the outcome of a single operation within a Batch call.
+// Err is set when that individual operation failed, without failing the
+// rest of the batch.
+type BatchResult struct {
+	Index      int             `json:"index"`
+	StatusCode int             `json:"statusCode"`
+	Body       json.RawMessage `json:"body"`
+	Err        error           `json:"-"`
+}
+
+// Batch performs batch operations, returning one BatchResult per operation
+// so individual failures don't fail the whole call.
+func (sdk *BrainAISDK) Batch(ctx context.Context, operations []BatchOperation) ([]BatchResult, error) {
 	request := map[string]interface{}{
 		"operations": operations,
 	}
-	
-	result, err := sdk.makeRequest("/api/batch", "POST", request)
+
+	result, err := sdk.makeRequest(ctx, "/api/batch", "POST", request)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	results, ok := result["results"].([]interface{})
 	if !ok {
-		return []map[string]interface{}{}, nil
+		return []BatchResult{}, nil
 	}
-	
-	batchResults := make([]map[string]interface{}, 0, len(results))
-	for _, r := range results {
-		if rMap, ok := r.(map[string]interface{}); ok {
-			batchResults = append(batchResults, rMap)
+
+	batchResults := make([]BatchResult, 0, len(results))
+	for i, r := range results {
+		rMap, ok := r.(map[string]interface{})
+		if !ok {
+			batchResults = append(batchResults, BatchResult{
+				Index: i,
+				Err:   fmt.Errorf("batch operation %d returned a malformed result", i),
+			})
+			continue
+		}
+
+		batchResult := BatchResult{
+			Index:      i,
+			StatusCode: int(getFloat64(rMap["statusCode"])),
 		}
+		if body, err := json.Marshal(rMap["body"]); err == nil {
+			batchResult.Body = body
+		}
+		if errMsg := getString(rMap["error"]); errMsg != "" {
+			batchResult.Err = errors.New(errMsg)
+		} else if batchResult.StatusCode >= 400 {
+			batchResult.Err = fmt.Errorf("batch operation %d failed with status %d", i, batchResult.StatusCode)
+		}
+
+		batchResults = append(batchResults, batchResult)
 	}
-	
+
 	return batchResults, nil
 }
 
 // HealthCheck performs health check
-func (sdk *BrainAISDK) HealthCheck() (bool, error) {
-	status, err := sdk.GetStatus()
+func (sdk *BrainAISDK) HealthCheck(ctx context.Context) (bool, error) {
+	status, err := sdk.GetStatus(ctx)
 	if err != nil {
 		log.Printf("Health check failed: %v", err)
 		return false, err
 	}
-	
+
 	if statusStatus, ok := status["status"].(string); ok {
 		return statusStatus == "healthy", nil
 	}
-	
+
 	return false, nil
 }
 
@@ -693,48 +1223,10 @@ func (vu VectorUtils) RandomVector(dimensions int, min, max float64) []float64 {
 	return vector
 }
 
-// ClientFactory manages Brain AI SDK instances
-type ClientFactory struct {
-	mu       sync.RWMutex
-	clients  map[string]*BrainAISDK
-}
-
-var factory = &ClientFactory{
-	clients: make(map[string]*BrainAISDK),
-}
-
-// GetInstance gets or creates a Brain AI SDK instance
-func (f *ClientFactory) GetInstance(config BrainAIConfig, name string) *BrainAISDK {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	
-	if client, exists := f.clients[name]; exists {
-		return client
-	}
-	
-	client := NewBrainAISDK(config)
-	f.clients[name] = client
-	return client
-}
-
-// RemoveInstance removes a Brain AI SDK instance
-func (f *ClientFactory) RemoveInstance(name string) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	delete(f.clients, name)
-}
-
-// ClearAll removes all Brain AI SDK instances
-func (f *ClientFactory) ClearAll() {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	clear(f.clients)
-}
-
 // Example usage
 func main() {
 	// Create configuration
-	config := BrainAISDK{
+	config := BrainAIConfig{
 		BaseURL:             "http://localhost:8000",
 		Timeout:             30000,
 		MemorySize:          10000,
@@ -745,56 +1237,59 @@ func main() {
 	
 	// Create SDK instance
 	sdk := NewBrainAISDK(config)
-	
+	defer sdk.Close()
+
+	ctx := context.Background()
+
 	// Example: Store memory
 	content := map[string]interface{}{
 		"text":    "This is a test memory",
 		"context": "testing",
 	}
-	
-	id, err := sdk.StoreMemory(content, SemanticMemory, map[string]interface{}{
+
+	id, err := sdk.StoreMemory(ctx, content, SemanticMemory, map[string]interface{}{
 		"importance": 0.8,
 	})
 	if err != nil {
 		log.Fatalf("Failed to store memory: %v", err)
 	}
-	
+
 	fmt.Printf("Stored memory with ID: %s\n", id)
-	
+
 	// Example: Search memories
-	searchResults, err := sdk.SearchMemories("test memory", 5)
+	searchResults, err := sdk.SearchMemories(ctx, "test memory", 5)
 	if err != nil {
 		log.Fatalf("Failed to search memories: %v", err)
 	}
-	
+
 	fmt.Printf("Found %d results\n", len(searchResults))
 	for _, result := range searchResults {
 		fmt.Printf("Result ID: %s, Score: %.2f\n", result.ID, result.Score)
 	}
-	
+
 	// Example: Learn pattern
-	err = sdk.Learn("user_pattern", []string{"context1", "context2"})
+	err = sdk.Learn(ctx, "user_pattern", []string{"context1", "context2"})
 	if err != nil {
 		log.Fatalf("Failed to learn pattern: %v", err)
 	}
-	
+
 	fmt.Println("Learned pattern successfully")
-	
+
 	// Example: Perform reasoning
-	reasoningResult, err := sdk.Reason("What is the meaning of life?", []string{"philosophy"})
+	reasoningResult, err := sdk.Reason(ctx, "What is the meaning of life?", []string{"philosophy"})
 	if err != nil {
 		log.Fatalf("Failed to reason: %v", err)
 	}
-	
-	fmt.Printf("Reasoning conclusion: %s (confidence: %.2f)\n", 
+
+	fmt.Printf("Reasoning conclusion: %s (confidence: %.2f)\n",
 		reasoningResult.Conclusion, reasoningResult.Confidence)
-	
+
 	// Example: Health check
-	isHealthy, err := sdk.HealthCheck()
+	isHealthy, err := sdk.HealthCheck(ctx)
 	if err != nil {
 		log.Fatalf("Health check failed: %v", err)
 	}
-	
+
 	if isHealthy {
 		fmt.Println("System is healthy")
 	} else {
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jitenkr2030/Brain-AI-Framework/sdk/go/embedder/hashembed"
+)
+
+func TestMakeRequestHonorsContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := sdk.GetStatus(ctx); err == nil {
+		t.Fatal("expected GetStatus to fail once its context deadline elapses")
+	}
+}
+
+func TestCloseAbortsInFlightRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := sdk.GetStatus(context.Background())
+		errCh <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := sdk.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("expected GetStatus to fail after Close")
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("GetStatus did not return after Close")
+	}
+}
+
+func TestSetReadDeadlineCancelsSlowRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+	sdk.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+
+	if _, err := sdk.GetStatus(context.Background()); err == nil {
+		t.Fatal("expected GetStatus to fail once the read deadline elapses")
+	}
+}
+
+func TestReasonStreamEmitsStepsThenFinal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		flusher, _ := w.(http.Flusher)
+		for i := 0; i < 3; i++ {
+			final := i == 2
+			fmt.Fprintf(w, `{"step_index":%d,"partial_conclusion":"step %d","confidence":0.%d,"evidence":["e%d"],"final":%t}`+"\n", i, i, i+1, i, final)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	steps, errs := sdk.ReasonStream(context.Background(), "why?", []string{"testing"})
+
+	var got []ReasoningStep
+	for step := range steps {
+		got = append(got, step)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("ReasonStream returned an error: %v", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(got))
+	}
+	if !got[2].Final {
+		t.Fatal("expected the last step to be marked final")
+	}
+}
+
+func TestCollectReasoningMergesSteps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"step_index":0,"partial_conclusion":"maybe","confidence":0.4,"evidence":["a"],"final":false}`)
+		fmt.Fprintln(w, `{"step_index":1,"partial_conclusion":"likely","confidence":0.9,"evidence":["b"],"final":true}`)
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	steps, errs := sdk.ReasonStream(context.Background(), "why?", nil)
+	result, err := CollectReasoning(steps, errs)
+	if err != nil {
+		t.Fatalf("CollectReasoning returned an error: %v", err)
+	}
+
+	if result.Conclusion != "likely" {
+		t.Fatalf("expected final conclusion %q, got %q", "likely", result.Conclusion)
+	}
+	if len(result.SupportingEvidence) != 2 {
+		t.Fatalf("expected 2 pieces of evidence, got %d", len(result.SupportingEvidence))
+	}
+}
+
+func TestStoreAndSearchMemoriesWithEmbedder(t *testing.T) {
+	sdk := NewBrainAISDK(BrainAIConfig{
+		LocalMode:        true,
+		VectorDimensions: 16,
+		Embedder:         hashembed.New(16),
+	})
+	defer sdk.Close()
+
+	ctx := context.Background()
+
+	if _, err := sdk.StoreMemory(ctx, "the sky is blue", SemanticMemory, nil); err != nil {
+		t.Fatalf("StoreMemory returned an error: %v", err)
+	}
+
+	results, err := sdk.SearchMemories(ctx, "the sky is blue", 1)
+	if err != nil {
+		t.Fatalf("SearchMemories returned an error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Content != "the sky is blue" {
+		t.Fatalf("expected recovered content %q, got %q", "the sky is blue", results[0].Content)
+	}
+}
+
+func TestConcurrentStoreVectorWithoutInitialDimensionsDoesNotRace(t *testing.T) {
+	sdk := NewBrainAISDK(BrainAIConfig{LocalMode: true})
+	defer sdk.Close()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := sdk.StoreVector(ctx, []float64{1, 2, 3}, nil); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("StoreVector returned an error: %v", err)
+	}
+}
+
+func TestStoreMemoryRejectsNonStringContentWhenEmbedded(t *testing.T) {
+	sdk := NewBrainAISDK(BrainAIConfig{
+		LocalMode:        true,
+		VectorDimensions: 16,
+		Embedder:         hashembed.New(16),
+	})
+	defer sdk.Close()
+
+	if _, err := sdk.StoreMemory(context.Background(), map[string]interface{}{"not": "a string"}, SemanticMemory, nil); err == nil {
+		t.Fatal("expected StoreMemory to reject non-string content when an Embedder is configured")
+	}
+}
@@ -0,0 +1,176 @@
+/**
+ * Brain AI Framework - Go SDK
+ * Request coalescing for high-throughput ingestion pipelines
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// coalescedOp pairs a queued BatchOperation with the channel its eventual
+// BatchResult should be delivered on.
+type coalescedOp struct {
+	op     BatchOperation
+	result chan BatchResult
+}
+
+// CoalescingClient buffers StoreMemory, StoreVector, CreateGraphNode, and
+// ConnectGraphNodes calls and submits them as a single Batch request once
+// FlushInterval elapses or MaxOps is reached, returning a future to each
+// caller. This lets high-throughput ingestion pipelines reuse the SDK's
+// single-item APIs while getting order-of-magnitude fewer HTTP round trips.
+type CoalescingClient struct {
+	sdk           *BrainAISDK
+	flushInterval time.Duration
+	maxOps        int
+
+	mu      sync.Mutex
+	pending []coalescedOp
+	timer   *time.Timer
+	closed  bool
+}
+
+// NewCoalescingClient wraps sdk, buffering operations for up to
+// flushInterval or until maxOps have accumulated, whichever comes first.
+func NewCoalescingClient(sdk *BrainAISDK, flushInterval time.Duration, maxOps int) *CoalescingClient {
+	return &CoalescingClient{
+		sdk:           sdk,
+		flushInterval: flushInterval,
+		maxOps:        maxOps,
+	}
+}
+
+// StoreMemory queues a memory node for the next flush.
+func (c *CoalescingClient) StoreMemory(content interface{}, memoryType MemoryType, metadata map[string]interface{}) <-chan BatchResult {
+	memoryNode := MemoryNode{
+		Content:     content,
+		Type:        memoryType,
+		Strength:    1.0,
+		Timestamp:   time.Now().UnixMilli(),
+		Connections: []string{},
+		Metadata:    metadata,
+	}
+	return c.enqueue(BatchOperation{Type: "memory", Endpoint: "/api/memory", Method: "POST", Data: memoryNode})
+}
+
+// StoreVector queues a vector for the next flush.
+func (c *CoalescingClient) StoreVector(vector []float64, metadata map[string]interface{}) <-chan BatchResult {
+	vectorEntry := VectorEntry{
+		Vector:    vector,
+		Metadata:  metadata,
+		Timestamp: time.Now().UnixMilli(),
+	}
+	return c.enqueue(BatchOperation{Type: "vector", Endpoint: "/api/vector", Method: "POST", Data: vectorEntry})
+}
+
+// CreateGraphNode queues a graph node create/update for the next flush.
+func (c *CoalescingClient) CreateGraphNode(id, label, nodeType string, properties map[string]interface{}) <-chan BatchResult {
+	node := GraphNode{
+		ID:          id,
+		Label:       label,
+		Type:        nodeType,
+		Properties:  properties,
+		Connections: []string{},
+		Weight:      1.0,
+	}
+	return c.enqueue(BatchOperation{Type: "graphNode", Endpoint: "/api/graph/node", Method: "POST", Data: node})
+}
+
+// ConnectGraphNodes queues a graph edge for the next flush.
+func (c *CoalescingClient) ConnectGraphNodes(nodeID1, nodeID2 string, weight float64) <-chan BatchResult {
+	request := map[string]interface{}{
+		"nodeId1": nodeID1,
+		"nodeId2": nodeID2,
+		"weight":  weight,
+	}
+	return c.enqueue(BatchOperation{Type: "graphConnect", Endpoint: "/api/graph/connect", Method: "POST", Data: request})
+}
+
+func (c *CoalescingClient) enqueue(op BatchOperation) <-chan BatchResult {
+	result := make(chan BatchResult, 1)
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		result <- BatchResult{Err: fmt.Errorf("coalescing: client is closed")}
+		close(result)
+		return result
+	}
+
+	c.pending = append(c.pending, coalescedOp{op: op, result: result})
+	shouldFlushNow := len(c.pending) >= c.maxOps
+	if c.timer == nil && !shouldFlushNow {
+		c.timer = time.AfterFunc(c.flushInterval, func() { c.flush(context.Background()) })
+	}
+	c.mu.Unlock()
+
+	if shouldFlushNow {
+		c.flush(context.Background())
+	}
+
+	return result
+}
+
+// Flush submits any buffered operations immediately instead of waiting for
+// FlushInterval or MaxOps.
+func (c *CoalescingClient) Flush(ctx context.Context) {
+	c.flush(ctx)
+}
+
+func (c *CoalescingClient) flush(ctx context.Context) {
+	c.mu.Lock()
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	batch := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ops := make([]BatchOperation, len(batch))
+	for i, b := range batch {
+		ops[i] = b.op
+	}
+
+	results, err := c.sdk.Batch(ctx, ops)
+	if err != nil {
+		for _, b := range batch {
+			b.result <- BatchResult{Err: err}
+			close(b.result)
+		}
+		return
+	}
+
+	byIndex := make(map[int]BatchResult, len(results))
+	for _, result := range results {
+		byIndex[result.Index] = result
+	}
+
+	for i, b := range batch {
+		if result, ok := byIndex[i]; ok {
+			b.result <- result
+		} else {
+			b.result <- BatchResult{Index: i, Err: fmt.Errorf("coalescing: missing batch result for operation %d", i)}
+		}
+		close(b.result)
+	}
+}
+
+// Close flushes any buffered operations and prevents further calls from
+// being accepted.
+func (c *CoalescingClient) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.flush(context.Background())
+	return nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescingClientBatchesByMaxOps(t *testing.T) {
+	var requestCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+
+		var body struct {
+			Operations []BatchOperation `json:"operations"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		results := make([]map[string]interface{}, len(body.Operations))
+		for i := range body.Operations {
+			results[i] = map[string]interface{}{"statusCode": 200, "body": map[string]interface{}{"id": fmt.Sprintf("id-%d", i)}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	coalescer := NewCoalescingClient(sdk, time.Hour, 2)
+	defer coalescer.Close()
+
+	futures := []<-chan BatchResult{
+		coalescer.StoreVector([]float64{1, 2, 3}, nil),
+		coalescer.StoreVector([]float64{4, 5, 6}, nil),
+	}
+
+	for i, f := range futures {
+		select {
+		case result := <-f:
+			if result.Err != nil {
+				t.Fatalf("future %d returned an error: %v", i, result.Err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("future %d did not resolve", i)
+		}
+	}
+
+	if got := atomic.LoadInt32(&requestCount); got != 1 {
+		t.Fatalf("expected exactly 1 batched request, got %d", got)
+	}
+}
+
+func TestCoalescingClientFlushesOnInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{{"statusCode": 200}},
+		})
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	coalescer := NewCoalescingClient(sdk, 20*time.Millisecond, 100)
+	defer coalescer.Close()
+
+	future := coalescer.StoreVector([]float64{1, 2, 3}, nil)
+
+	select {
+	case result := <-future:
+		if result.Err != nil {
+			t.Fatalf("future returned an error: %v", result.Err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("future did not resolve once FlushInterval elapsed")
+	}
+}
+
+func TestBatchSurfacesPerOperationErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"statusCode": 200, "body": map[string]interface{}{"id": "ok"}},
+				{"statusCode": 500, "error": "boom"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	results, err := sdk.Batch(context.Background(), []BatchOperation{
+		{Type: "vector", Endpoint: "/api/vector", Method: "POST"},
+		{Type: "vector", Endpoint: "/api/vector", Method: "POST"},
+	})
+	if err != nil {
+		t.Fatalf("Batch returned an error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Err != nil {
+		t.Fatalf("expected the first operation to succeed, got %v", results[0].Err)
+	}
+	if results[1].Err == nil {
+		t.Fatal("expected the second operation to surface its error")
+	}
+}
+
+func TestBatchKeepsIndicesAlignedWhenAResultIsMalformed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []interface{}{
+				map[string]interface{}{"statusCode": 200, "body": map[string]interface{}{"id": "op0"}},
+				"not a result object",
+				map[string]interface{}{"statusCode": 200, "body": map[string]interface{}{"id": "op2"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	results, err := sdk.Batch(context.Background(), []BatchOperation{
+		{Type: "vector", Endpoint: "/api/vector", Method: "POST"},
+		{Type: "vector", Endpoint: "/api/vector", Method: "POST"},
+		{Type: "vector", Endpoint: "/api/vector", Method: "POST"},
+	})
+	if err != nil {
+		t.Fatalf("Batch returned an error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	byIndex := make(map[int]BatchResult, len(results))
+	for _, result := range results {
+		byIndex[result.Index] = result
+	}
+
+	if r := byIndex[0]; r.Err != nil || string(r.Body) != `{"id":"op0"}` {
+		t.Fatalf("expected operation 0's own result, got %+v", r)
+	}
+	if r := byIndex[1]; r.Err == nil {
+		t.Fatal("expected operation 1's malformed result to surface as an error on index 1")
+	}
+	if r := byIndex[2]; r.Err != nil || string(r.Body) != `{"id":"op2"}` {
+		t.Fatalf("expected operation 2's own result, got %+v", r)
+	}
+}
+
+func TestCoalescingClientFlushMatchesFuturesByResultIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []interface{}{
+				map[string]interface{}{"statusCode": 200, "body": map[string]interface{}{"id": "op0"}},
+				"not a result object",
+				map[string]interface{}{"statusCode": 200, "body": map[string]interface{}{"id": "op2"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{BaseURL: server.URL})
+	defer sdk.Close()
+
+	coalescer := NewCoalescingClient(sdk, time.Hour, 3)
+	defer coalescer.Close()
+
+	futures := []<-chan BatchResult{
+		coalescer.StoreVector([]float64{1, 2, 3}, nil),
+		coalescer.StoreVector([]float64{4, 5, 6}, nil),
+		coalescer.StoreVector([]float64{7, 8, 9}, nil),
+	}
+
+	var got [3]BatchResult
+	for i, f := range futures {
+		select {
+		case got[i] = <-f:
+		case <-time.After(time.Second):
+			t.Fatalf("future %d did not resolve", i)
+		}
+	}
+
+	if got[0].Err != nil || string(got[0].Body) != `{"id":"op0"}` {
+		t.Fatalf("expected future 0 to receive operation 0's own result, got %+v", got[0])
+	}
+	if got[1].Err == nil {
+		t.Fatal("expected future 1 to surface operation 1's malformed-result error, not operation 2's result")
+	}
+	if got[2].Err != nil || string(got[2].Body) != `{"id":"op2"}` {
+		t.Fatalf("expected future 2 to receive operation 2's own result, got %+v", got[2])
+	}
+}
@@ -0,0 +1,51 @@
+/**
+ * Brain AI Framework - Go SDK
+ * Deterministic hashing-based embedder, useful as a stand-in for a real
+ * model in tests and local development.
+ */
+
+package hashembed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Embedder turns text into a deterministic pseudo-embedding by hashing it
+// and expanding the digest into the requested number of dimensions. It
+// produces no semantic meaning, but identical input always yields an
+// identical vector, which is what most SDK tests need.
+type Embedder struct {
+	dimensions int
+}
+
+// New creates a hashing embedder that produces vectors with the given
+// number of dimensions.
+func New(dimensions int) *Embedder {
+	return &Embedder{dimensions: dimensions}
+}
+
+// Embed implements brain-ai's Embedder interface.
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	vector := make([]float64, e.dimensions)
+	digest := sha256.Sum256([]byte(text))
+
+	for i := range vector {
+		// Re-hash the digest with the dimension index folded in so we can
+		// derive more 32-bit words than a single sha256 sum provides.
+		seed := digest
+		seed[0] ^= byte(i)
+		seed[1] ^= byte(i >> 8)
+		block := sha256.Sum256(seed[:])
+		word := binary.LittleEndian.Uint32(block[:4])
+		vector[i] = (float64(word)/float64(^uint32(0)))*2 - 1 // map into [-1, 1]
+	}
+
+	return vector, nil
+}
+
+// Dimensions implements brain-ai's Embedder interface.
+func (e *Embedder) Dimensions() int {
+	return e.dimensions
+}
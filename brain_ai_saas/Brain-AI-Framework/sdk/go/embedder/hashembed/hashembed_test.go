@@ -0,0 +1,52 @@
+package hashembed
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEmbedIsDeterministic(t *testing.T) {
+	e := New(16)
+
+	a, err := e.Embed(context.Background(), "hello world")
+	if err != nil {
+		t.Fatalf("Embed returned an error: %v", err)
+	}
+	b, err := e.Embed(context.Background(), "hello world")
+	if err != nil {
+		t.Fatalf("Embed returned an error: %v", err)
+	}
+
+	if len(a) != e.Dimensions() {
+		t.Fatalf("expected %d dimensions, got %d", e.Dimensions(), len(a))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("expected identical input to produce identical vectors, differed at index %d", i)
+		}
+	}
+}
+
+func TestEmbedDiffersForDifferentText(t *testing.T) {
+	e := New(16)
+
+	a, err := e.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed returned an error: %v", err)
+	}
+	b, err := e.Embed(context.Background(), "goodbye")
+	if err != nil {
+		t.Fatalf("Embed returned an error: %v", err)
+	}
+
+	same := true
+	for i := range a {
+		if a[i] != b[i] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Fatal("expected different input to produce different vectors")
+	}
+}
@@ -0,0 +1,102 @@
+/**
+ * Brain AI Framework - Go SDK
+ * HTTP embedder that calls an OpenAI-compatible /embeddings endpoint.
+ */
+
+package httpembed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Config configures an Embedder.
+type Config struct {
+	// BaseURL is the root of an OpenAI-compatible API, e.g.
+	// "https://api.openai.com/v1". Embed POSTs to BaseURL+"/embeddings".
+	BaseURL string
+	APIKey  string
+	Model   string
+
+	// Dimensions is the size of the vectors the endpoint returns for Model.
+	Dimensions int
+
+	// HTTPClient is used for requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Embedder calls a remote OpenAI-compatible embeddings endpoint.
+type Embedder struct {
+	config Config
+	client *http.Client
+}
+
+// New creates an HTTP embedder from config.
+func New(config Config) *Embedder {
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Embedder{config: config, client: client}
+}
+
+type embeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed implements brain-ai's Embedder interface.
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	body, err := json.Marshal(embeddingRequest{Model: e.config.Model, Input: text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.BaseURL+"/embeddings", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("httpembed: embeddings request failed with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var parsed embeddingResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, err
+	}
+	if len(parsed.Data) == 0 {
+		return nil, fmt.Errorf("httpembed: embeddings response contained no data")
+	}
+
+	return parsed.Data[0].Embedding, nil
+}
+
+// Dimensions implements brain-ai's Embedder interface.
+func (e *Embedder) Dimensions() int {
+	return e.config.Dimensions
+}
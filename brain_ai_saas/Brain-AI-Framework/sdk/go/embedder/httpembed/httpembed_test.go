@@ -0,0 +1,78 @@
+package httpembed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmbedPostsRequestAndParsesResponse(t *testing.T) {
+	var gotPath string
+	var gotAuth string
+	var gotBody embeddingRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&gotBody)
+
+		json.NewEncoder(w).Encode(embeddingResponse{
+			Data: []struct {
+				Embedding []float64 `json:"embedding"`
+			}{{Embedding: []float64{0.1, 0.2, 0.3}}},
+		})
+	}))
+	defer server.Close()
+
+	e := New(Config{BaseURL: server.URL, APIKey: "secret", Model: "text-embedding-3-small", Dimensions: 3})
+
+	vector, err := e.Embed(context.Background(), "hello world")
+	if err != nil {
+		t.Fatalf("Embed returned an error: %v", err)
+	}
+
+	if gotPath != "/embeddings" {
+		t.Fatalf("expected request to /embeddings, got %q", gotPath)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Fatalf("expected Authorization header %q, got %q", "Bearer secret", gotAuth)
+	}
+	if gotBody.Model != "text-embedding-3-small" || gotBody.Input != "hello world" {
+		t.Fatalf("unexpected request body: %+v", gotBody)
+	}
+	if len(vector) != 3 || vector[0] != 0.1 || vector[1] != 0.2 || vector[2] != 0.3 {
+		t.Fatalf("unexpected embedding: %v", vector)
+	}
+	if e.Dimensions() != 3 {
+		t.Fatalf("expected Dimensions() 3, got %d", e.Dimensions())
+	}
+}
+
+func TestEmbedReturnsErrorOnNon2xxResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid api key"}`))
+	}))
+	defer server.Close()
+
+	e := New(Config{BaseURL: server.URL})
+
+	if _, err := e.Embed(context.Background(), "hello"); err == nil {
+		t.Fatal("expected Embed to return an error on a 401 response")
+	}
+}
+
+func TestEmbedReturnsErrorWhenResponseHasNoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(embeddingResponse{})
+	}))
+	defer server.Close()
+
+	e := New(Config{BaseURL: server.URL})
+
+	if _, err := e.Embed(context.Background(), "hello"); err == nil {
+		t.Fatal("expected Embed to return an error when the response contains no data")
+	}
+}
@@ -0,0 +1,303 @@
+/**
+ * Brain AI Framework - Go SDK
+ * Retry with backoff and a per-endpoint circuit breaker for makeRequest
+ */
+
+package main
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCircuitOpen is returned by makeRequest while an endpoint's circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("brain-ai: circuit breaker open")
+
+// RetryPolicy configures how makeRequest retries retryable failures and
+// trips the per-endpoint circuit breaker.
+type RetryPolicy struct {
+	MaxAttempts    int
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	Multiplier     float64
+	JitterFraction float64
+
+	// RetryOn decides whether a failed attempt should be retried. status
+	// is 0 when the request never reached the server (e.g. a network
+	// error). If nil, defaultRetryOn is used.
+	RetryOn func(status int, err error) bool
+
+	// CircuitBreakerThreshold is the number of consecutive failures on an
+	// endpoint that trips its breaker open. Zero disables the breaker.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerOpenDuration is how long a tripped breaker stays open
+	// before allowing a single half-open probe request through.
+	CircuitBreakerOpenDuration time.Duration
+}
+
+// DefaultRetryPolicy returns the RetryPolicy NewBrainAISDK applies when
+// none is configured: up to 3 attempts, 200ms-5s exponential backoff with
+// 10% jitter, and a breaker that opens for 30s after 5 consecutive
+// failures on the same endpoint.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:                3,
+		BaseDelay:                  200 * time.Millisecond,
+		MaxDelay:                   5 * time.Second,
+		Multiplier:                 2.0,
+		JitterFraction:             0.1,
+		RetryOn:                    defaultRetryOn,
+		CircuitBreakerThreshold:    5,
+		CircuitBreakerOpenDuration: 30 * time.Second,
+	}
+}
+
+func defaultRetryOn(status int, err error) bool {
+	if err != nil && status == 0 {
+		return true
+	}
+	return status == http.StatusTooManyRequests || (status >= 500 && status < 600)
+}
+
+// retryBackoff computes the delay before the given (zero-indexed) retry
+// attempt: min(MaxDelay, BaseDelay*Multiplier^attempt), jittered by
+// +/-JitterFraction.
+func retryBackoff(policy RetryPolicy, attempt int) time.Duration {
+	delay := float64(policy.BaseDelay) * math.Pow(policy.Multiplier, float64(attempt))
+	if max := float64(policy.MaxDelay); policy.MaxDelay > 0 && delay > max {
+		delay = max
+	}
+
+	jitter := delay * policy.JitterFraction * (2*rand.Float64() - 1)
+	delay += jitter
+	if delay < 0 {
+		delay = 0
+	}
+	return time.Duration(delay)
+}
+
+// retryAfterDuration parses an HTTP Retry-After header value given in
+// seconds, returning 0 if it's absent or malformed.
+func retryAfterDuration(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// CircuitState is the state of a per-endpoint circuit breaker.
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker is a simple closed/open/half-open breaker keyed by
+// endpoint: it opens after a run of consecutive failures and, once
+// CircuitBreakerOpenDuration has passed, lets exactly one probe request
+// through before deciding whether to close or reopen.
+type circuitBreaker struct {
+	threshold    int
+	openDuration time.Duration
+
+	mu                  sync.Mutex
+	state               CircuitState
+	consecutiveFailures int
+	openUntil           time.Time
+	probeInFlight       bool
+}
+
+func newCircuitBreaker(threshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, openDuration: openDuration}
+}
+
+// Allow reports whether a request may proceed, transitioning an open
+// breaker to half-open (admitting a single probe) once openDuration has
+// elapsed.
+func (cb *circuitBreaker) Allow() bool {
+	if cb == nil || cb.threshold <= 0 {
+		return true
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if time.Now().Before(cb.openUntil) {
+			return false
+		}
+		cb.state = CircuitHalfOpen
+		cb.probeInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if cb.probeInFlight {
+			return false
+		}
+		cb.probeInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the breaker and clears its failure count.
+func (cb *circuitBreaker) RecordSuccess() {
+	if cb == nil || cb.threshold <= 0 {
+		return
+	}
+	cb.mu.Lock()
+	cb.state = CircuitClosed
+	cb.consecutiveFailures = 0
+	cb.probeInFlight = false
+	cb.mu.Unlock()
+}
+
+// RecordFailure counts a failure, tripping the breaker open once
+// consecutive failures reach threshold (or immediately re-opening it if
+// the failure was the half-open probe).
+func (cb *circuitBreaker) RecordFailure() {
+	if cb == nil || cb.threshold <= 0 {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probeInFlight = false
+	if cb.state == CircuitHalfOpen {
+		cb.open()
+		return
+	}
+
+	cb.consecutiveFailures++
+	if cb.consecutiveFailures >= cb.threshold {
+		cb.open()
+	}
+}
+
+func (cb *circuitBreaker) open() {
+	cb.state = CircuitOpen
+	cb.openUntil = time.Now().Add(cb.openDuration)
+	cb.consecutiveFailures = 0
+}
+
+// State returns the breaker's current state.
+func (cb *circuitBreaker) State() CircuitState {
+	if cb == nil || cb.threshold <= 0 {
+		return CircuitClosed
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// routeStaticSegments are the fixed path words used across the SDK's
+// endpoints. Any other segment is assumed to be an interpolated id.
+var routeStaticSegments = map[string]bool{
+	"":           true,
+	"api":        true,
+	"memory":     true,
+	"strength":   true,
+	"vector":     true,
+	"search":     true,
+	"graph":      true,
+	"node":       true,
+	"connect":    true,
+	"neighbors":  true,
+	"learning":   true,
+	"learn":      true,
+	"patterns":   true,
+	"reasoning":  true,
+	"reason":     true,
+	"stats":      true,
+	"feedback":   true,
+	"status":     true,
+	"statistics": true,
+	"clear":      true,
+	"batch":      true,
+	"health":     true,
+}
+
+// routeKey collapses path-parameterized segments (ids interpolated by
+// callers like GetMemory and GetGraphNeighbors) out of endpoint, so every
+// id shares one circuit breaker instead of leaking one per id ever
+// requested.
+func routeKey(endpoint string) string {
+	segments := strings.Split(endpoint, "/")
+	for i, seg := range segments {
+		if !routeStaticSegments[seg] {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// breakerFor returns the circuit breaker for endpoint's route, creating it
+// on first use. Endpoints are keyed by routeKey rather than the literal
+// string so e.g. "/api/memory/abc" and "/api/memory/xyz" share a breaker.
+func (sdk *BrainAISDK) breakerFor(endpoint string) *circuitBreaker {
+	key := routeKey(endpoint)
+
+	sdk.breakersMu.Lock()
+	defer sdk.breakersMu.Unlock()
+
+	cb, ok := sdk.breakers[key]
+	if !ok {
+		cb = newCircuitBreaker(sdk.config.RetryPolicy.CircuitBreakerThreshold, sdk.config.RetryPolicy.CircuitBreakerOpenDuration)
+		sdk.breakers[key] = cb
+	}
+	return cb
+}
+
+// Metrics is a point-in-time snapshot of request/retry counters and
+// per-endpoint circuit breaker state, suitable for exporting to
+// Prometheus or similar.
+type Metrics struct {
+	RequestsTotal uint64
+	RetriesTotal  uint64
+	CircuitState  map[string]string
+}
+
+// Metrics returns a snapshot of this SDK instance's request counters and
+// circuit breaker states, keyed by route (the endpoint with interpolated ids
+// replaced by ":id", as computed by routeKey).
+func (sdk *BrainAISDK) Metrics() Metrics {
+	sdk.breakersMu.Lock()
+	states := make(map[string]string, len(sdk.breakers))
+	for endpoint, cb := range sdk.breakers {
+		states[endpoint] = cb.State().String()
+	}
+	sdk.breakersMu.Unlock()
+
+	return Metrics{
+		RequestsTotal: atomic.LoadUint64(&sdk.requestsTotal),
+		RetriesTotal:  atomic.LoadUint64(&sdk.retriesTotal),
+		CircuitState:  states,
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMakeRequestRetriesOnServerErrorThenSucceeds(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{
+		BaseURL: server.URL,
+		RetryPolicy: RetryPolicy{
+			MaxAttempts:    3,
+			BaseDelay:      time.Millisecond,
+			MaxDelay:       10 * time.Millisecond,
+			Multiplier:     2.0,
+			JitterFraction: 0,
+			RetryOn:        defaultRetryOn,
+		},
+	})
+	defer sdk.Close()
+
+	if _, err := sdk.GetStatus(context.Background()); err != nil {
+		t.Fatalf("GetStatus returned an error after retrying: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+
+	metrics := sdk.Metrics()
+	if metrics.RequestsTotal != 3 {
+		t.Fatalf("expected RequestsTotal 3, got %d", metrics.RequestsTotal)
+	}
+	if metrics.RetriesTotal != 2 {
+		t.Fatalf("expected RetriesTotal 2, got %d", metrics.RetriesTotal)
+	}
+}
+
+func TestCircuitBreakerIsSharedAcrossInterpolatedIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{
+		BaseURL: server.URL,
+		RetryPolicy: RetryPolicy{
+			MaxAttempts:                1,
+			BaseDelay:                  time.Millisecond,
+			MaxDelay:                   time.Millisecond,
+			Multiplier:                 1,
+			RetryOn:                    defaultRetryOn,
+			CircuitBreakerThreshold:    5,
+			CircuitBreakerOpenDuration: time.Minute,
+		},
+	})
+	defer sdk.Close()
+
+	ctx := context.Background()
+	ids := []string{"a", "b", "c", "d", "e"}
+	for _, id := range ids {
+		if _, err := sdk.GetMemory(ctx, id); err == nil {
+			t.Fatalf("expected GetMemory(%q) to fail while the endpoint is erroring", id)
+		}
+	}
+
+	if _, err := sdk.GetMemory(ctx, "f"); err != ErrCircuitOpen {
+		t.Fatalf("expected ErrCircuitOpen once 5 distinct ids have failed, got %v", err)
+	}
+
+	metrics := sdk.Metrics()
+	if len(metrics.CircuitState) != 1 {
+		t.Fatalf("expected a single shared breaker for /api/memory/:id, got %d entries: %v", len(metrics.CircuitState), metrics.CircuitState)
+	}
+}
+
+func TestMakeRequestTripsCircuitBreakerAfterConsecutiveFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sdk := NewBrainAISDK(BrainAIConfig{
+		BaseURL: server.URL,
+		RetryPolicy: RetryPolicy{
+			MaxAttempts:                1,
+			BaseDelay:                  time.Millisecond,
+			MaxDelay:                   time.Millisecond,
+			Multiplier:                 1,
+			RetryOn:                    defaultRetryOn,
+			CircuitBreakerThreshold:    2,
+			CircuitBreakerOpenDuration: time.Minute,
+		},
+	})
+	defer sdk.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := sdk.GetStatus(ctx); err == nil {
+			t.Fatal("expected GetStatus to fail while the endpoint is erroring")
+		}
+	}
+
+	if _, err := sdk.GetStatus(ctx); err != ErrCircuitOpen {
+		t.Fatalf("expected ErrCircuitOpen once the breaker trips, got %v", err)
+	}
+
+	metrics := sdk.Metrics()
+	if got := metrics.CircuitState["/api/status"]; got != "open" {
+		t.Fatalf("expected circuit state %q, got %q", "open", got)
+	}
+}
@@ -0,0 +1,263 @@
+/**
+ * Brain AI Framework - Go SDK
+ * Multi-tenant client factory: per-tenant rate limits, quotas, and LRU eviction
+ */
+
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// TenantID identifies a tenant whose requests are isolated behind their own
+// BrainAISDK instance, rate limiter, and quota tracking.
+type TenantID string
+
+// TenantOptions configures the BrainAISDK ClientFactory.GetInstance creates
+// for a tenant on first use. RateLimitRPS is enforced by the factory's
+// limiter; MemoryQuota is tracked and reported but does not block requests.
+// Config is forwarded to NewBrainAISDK unchanged.
+type TenantOptions struct {
+	Config BrainAIConfig
+
+	// RateLimitRPS caps sustained requests/second via a token bucket
+	// injected into the tenant's makeRequest calls. Zero means unlimited.
+	RateLimitRPS float64
+
+	// MemoryQuota is the number of requests after which Stats reports the
+	// tenant as exhausted and a TenantQuotaExceeded event fires. Requests
+	// past the quota are still sent. Zero means unlimited.
+	MemoryQuota int64
+}
+
+// TenantEventType enumerates the kinds of event TenantEvent carries.
+type TenantEventType int
+
+const (
+	// TenantQuotaExceeded fires the first time a tenant's request count
+	// reaches its MemoryQuota.
+	TenantQuotaExceeded TenantEventType = iota
+	// TenantEvicted fires when a tenant's client is dropped, whether by
+	// LRU eviction or an explicit RemoveInstance.
+	TenantEvicted
+)
+
+func (t TenantEventType) String() string {
+	switch t {
+	case TenantQuotaExceeded:
+		return "quota_exceeded"
+	case TenantEvicted:
+		return "evicted"
+	default:
+		return "unknown"
+	}
+}
+
+// TenantEvent is delivered on a tenant's Watch channel when its quota trips
+// or its client is evicted.
+type TenantEvent struct {
+	Tenant TenantID
+	Type   TenantEventType
+	At     time.Time
+}
+
+// TenantStats is a point-in-time snapshot of one tenant's usage, returned
+// by ClientFactory.Stats.
+type TenantStats struct {
+	RequestsTotal uint64
+	QuotaLimit    int64
+	QuotaExceeded bool
+}
+
+// tenantEntry is the factory's bookkeeping for one live tenant client.
+type tenantEntry struct {
+	sdk  *BrainAISDK
+	opts TenantOptions
+	elem *list.Element // position in ClientFactory.lru; most-recently-used at Front
+
+	mu            sync.Mutex
+	quotaExceeded bool
+}
+
+// ClientFactory is a multi-tenant registry of BrainAISDK instances. Each
+// tenant gets its own client, rate limiter, and quota tracking; once more
+// than MaxTenants clients are live the factory evicts the least-recently
+// used one, mirroring the sharded per-key client pools common in
+// Kubernetes multi-cluster libraries.
+type ClientFactory struct {
+	// MaxTenants caps the number of live tenant clients. Zero or negative
+	// disables eviction.
+	MaxTenants int
+
+	mu      sync.Mutex
+	tenants map[TenantID]*tenantEntry
+	lru     *list.List // holds TenantID values; most-recently-used at Front
+
+	watchMu  sync.Mutex
+	watchers map[TenantID][]chan TenantEvent
+}
+
+var factory = NewClientFactory(0)
+
+// NewClientFactory creates a ClientFactory that evicts the least-recently
+// used tenant once more than maxTenants clients are live. maxTenants <= 0
+// disables eviction.
+func NewClientFactory(maxTenants int) *ClientFactory {
+	return &ClientFactory{
+		MaxTenants: maxTenants,
+		tenants:    make(map[TenantID]*tenantEntry),
+		lru:        list.New(),
+		watchers:   make(map[TenantID][]chan TenantEvent),
+	}
+}
+
+// GetInstance returns tenant's BrainAISDK client, creating it from opts on
+// first use and marking it most-recently-used. If tenant already has a
+// live client, it is returned as-is and opts is ignored, matching
+// NewBrainAISDK's own configure-once-at-creation convention.
+func (f *ClientFactory) GetInstance(tenant TenantID, opts TenantOptions) *BrainAISDK {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if entry, ok := f.tenants[tenant]; ok {
+		f.lru.MoveToFront(entry.elem)
+		return entry.sdk
+	}
+
+	sdk := NewBrainAISDK(opts.Config)
+	if opts.RateLimitRPS > 0 {
+		burst := int(opts.RateLimitRPS)
+		if burst < 1 {
+			burst = 1
+		}
+		sdk.limiter = rate.NewLimiter(rate.Limit(opts.RateLimitRPS), burst)
+	}
+
+	entry := &tenantEntry{sdk: sdk, opts: opts}
+	entry.elem = f.lru.PushFront(tenant)
+	f.tenants[tenant] = entry
+
+	if opts.MemoryQuota > 0 {
+		quota := uint64(opts.MemoryQuota)
+		sdk.onRequest = func(total uint64) {
+			if total < quota {
+				return
+			}
+			entry.mu.Lock()
+			alreadyTripped := entry.quotaExceeded
+			entry.quotaExceeded = true
+			entry.mu.Unlock()
+			if !alreadyTripped {
+				f.emit(tenant, TenantQuotaExceeded)
+			}
+		}
+	}
+
+	f.evictLocked()
+	return sdk
+}
+
+// RemoveInstance closes and drops tenant's client, if any, and emits a
+// TenantEvicted event.
+func (f *ClientFactory) RemoveInstance(tenant TenantID) {
+	f.mu.Lock()
+	entry, ok := f.tenants[tenant]
+	if ok {
+		delete(f.tenants, tenant)
+		f.lru.Remove(entry.elem)
+	}
+	f.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	entry.sdk.Close()
+	f.emit(tenant, TenantEvicted)
+}
+
+// ClearAll closes and drops every tenant's client without emitting
+// eviction events.
+func (f *ClientFactory) ClearAll() {
+	f.mu.Lock()
+	tenants := f.tenants
+	f.tenants = make(map[TenantID]*tenantEntry)
+	f.lru.Init()
+	f.mu.Unlock()
+
+	for _, entry := range tenants {
+		entry.sdk.Close()
+	}
+}
+
+// Stats returns a snapshot of every live tenant's request count and quota
+// usage.
+func (f *ClientFactory) Stats() map[TenantID]TenantStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := make(map[TenantID]TenantStats, len(f.tenants))
+	for tenant, entry := range f.tenants {
+		entry.mu.Lock()
+		exceeded := entry.quotaExceeded
+		entry.mu.Unlock()
+
+		stats[tenant] = TenantStats{
+			RequestsTotal: entry.sdk.Metrics().RequestsTotal,
+			QuotaLimit:    entry.opts.MemoryQuota,
+			QuotaExceeded: exceeded,
+		}
+	}
+	return stats
+}
+
+// Watch returns a channel that receives a TenantEvent whenever tenant's
+// quota trips or its client is evicted. The channel is buffered; a slow
+// consumer drops events rather than blocking the factory.
+func (f *ClientFactory) Watch(tenant TenantID) <-chan TenantEvent {
+	ch := make(chan TenantEvent, 8)
+
+	f.watchMu.Lock()
+	f.watchers[tenant] = append(f.watchers[tenant], ch)
+	f.watchMu.Unlock()
+
+	return ch
+}
+
+func (f *ClientFactory) emit(tenant TenantID, eventType TenantEventType) {
+	f.watchMu.Lock()
+	channels := f.watchers[tenant]
+	f.watchMu.Unlock()
+
+	event := TenantEvent{Tenant: tenant, Type: eventType, At: time.Now()}
+	for _, ch := range channels {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// evictLocked drops the least-recently-used tenant until at most
+// MaxTenants remain live. Callers must hold f.mu.
+func (f *ClientFactory) evictLocked() {
+	if f.MaxTenants <= 0 {
+		return
+	}
+	for len(f.tenants) > f.MaxTenants {
+		back := f.lru.Back()
+		if back == nil {
+			return
+		}
+		tenant := back.Value.(TenantID)
+		entry := f.tenants[tenant]
+		delete(f.tenants, tenant)
+		f.lru.Remove(back)
+
+		entry.sdk.Close()
+		f.emit(tenant, TenantEvicted)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetInstanceReusesExistingTenant(t *testing.T) {
+	f := NewClientFactory(0)
+	defer f.ClearAll()
+
+	a := f.GetInstance("tenant-a", TenantOptions{Config: BrainAIConfig{BaseURL: "http://a.example"}})
+	b := f.GetInstance("tenant-a", TenantOptions{Config: BrainAIConfig{BaseURL: "http://ignored.example"}})
+
+	if a != b {
+		t.Fatal("expected GetInstance to return the same client for an already-live tenant")
+	}
+}
+
+func TestClientFactoryEvictsLeastRecentlyUsedTenant(t *testing.T) {
+	f := NewClientFactory(2)
+	defer f.ClearAll()
+
+	events := f.Watch("tenant-a")
+
+	f.GetInstance("tenant-a", TenantOptions{})
+	f.GetInstance("tenant-b", TenantOptions{})
+	// Touch tenant-a so tenant-b becomes the least-recently-used one.
+	f.GetInstance("tenant-a", TenantOptions{})
+	f.GetInstance("tenant-c", TenantOptions{})
+
+	stats := f.Stats()
+	if _, ok := stats["tenant-b"]; ok {
+		t.Fatal("expected tenant-b to have been evicted")
+	}
+	if _, ok := stats["tenant-a"]; !ok {
+		t.Fatal("expected tenant-a to remain live")
+	}
+	if _, ok := stats["tenant-c"]; !ok {
+		t.Fatal("expected tenant-c to remain live")
+	}
+
+	select {
+	case event := <-events:
+		t.Fatalf("expected no eviction event for tenant-a, got %v", event)
+	default:
+	}
+}
+
+func TestClientFactoryEmitsQuotaExceededEvent(t *testing.T) {
+	f := NewClientFactory(0)
+	defer f.ClearAll()
+
+	events := f.Watch("tenant-a")
+	sdk := f.GetInstance("tenant-a", TenantOptions{MemoryQuota: 1})
+
+	// Drive the onRequest hook the same way makeRequest does, without
+	// needing a live HTTP server.
+	sdk.onRequest(1)
+
+	select {
+	case event := <-events:
+		if event.Type != TenantQuotaExceeded {
+			t.Fatalf("expected TenantQuotaExceeded, got %v", event.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a TenantQuotaExceeded event")
+	}
+
+	stats := f.Stats()["tenant-a"]
+	if !stats.QuotaExceeded {
+		t.Fatal("expected Stats to report the quota as exceeded")
+	}
+}
+
+func TestRemoveInstanceEmitsEvictedEvent(t *testing.T) {
+	f := NewClientFactory(0)
+	defer f.ClearAll()
+
+	events := f.Watch("tenant-a")
+	f.GetInstance("tenant-a", TenantOptions{})
+	f.RemoveInstance("tenant-a")
+
+	select {
+	case event := <-events:
+		if event.Type != TenantEvicted {
+			t.Fatalf("expected TenantEvicted, got %v", event.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a TenantEvicted event")
+	}
+
+	if _, ok := f.Stats()["tenant-a"]; ok {
+		t.Fatal("expected tenant-a to be gone from Stats after RemoveInstance")
+	}
+}
@@ -0,0 +1,571 @@
+/**
+ * Brain AI Framework - Go SDK
+ * In-process HNSW vector index with on-disk persistence
+ */
+
+package vectorindex
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Distance identifies the similarity metric used to compare vectors.
+type Distance int
+
+const (
+	Cosine Distance = iota
+	Euclidean
+)
+
+// SearchResult is a single match returned by the index.
+type SearchResult struct {
+	ID       string
+	Score    float64
+	Metadata map[string]interface{}
+}
+
+// Filter decides whether a candidate's metadata should be considered during a search.
+type Filter func(metadata map[string]interface{}) bool
+
+const magic = "BAIHNSW1"
+
+// node is a single point stored in the graph, with per-layer neighbor lists.
+type node struct {
+	id        string
+	vector    []float64
+	metadata  map[string]interface{}
+	level     int
+	neighbors [][]string // neighbors[layer] = neighbor ids, layer 0 holds up to 2M
+}
+
+// Index is an in-process Hierarchical Navigable Small World graph.
+//
+// It supports insertion and approximate nearest-neighbor search without a
+// remote server, and can snapshot itself to disk so it survives restarts.
+type Index struct {
+	mu sync.RWMutex
+
+	dim      int
+	distance Distance
+
+	m              int // max bidirectional neighbors per node at layer >= 1
+	mMax0          int // max neighbors at layer 0 (2*m)
+	efConstruction int
+	efSearch       int
+	mL             float64
+
+	nodes      map[string]*node
+	entryPoint string
+	maxLevel   int
+}
+
+// Option configures an Index at construction time.
+type Option func(*Index)
+
+// WithM sets the maximum number of bidirectional neighbors per node at layers
+// above 0 (layer 0 keeps 2*M). Defaults to 16.
+func WithM(m int) Option {
+	return func(idx *Index) { idx.m = m }
+}
+
+// WithEfConstruction sets the candidate list size used while inserting.
+// Defaults to 200.
+func WithEfConstruction(ef int) Option {
+	return func(idx *Index) { idx.efConstruction = ef }
+}
+
+// WithEfSearch sets the candidate list size used while searching.
+// Defaults to 64.
+func WithEfSearch(ef int) Option {
+	return func(idx *Index) { idx.efSearch = ef }
+}
+
+// New creates an empty index over vectors of the given dimensionality.
+func New(dim int, distance Distance, opts ...Option) *Index {
+	idx := &Index{
+		dim:            dim,
+		distance:       distance,
+		m:              16,
+		efConstruction: 200,
+		efSearch:       64,
+		nodes:          make(map[string]*node),
+		maxLevel:       -1,
+	}
+	for _, opt := range opts {
+		opt(idx)
+	}
+	idx.mMax0 = idx.m * 2
+	idx.mL = 1 / math.Log(float64(idx.m))
+	return idx
+}
+
+// Len returns the number of vectors currently stored in the index.
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.nodes)
+}
+
+func (idx *Index) dist(a, b []float64) float64 {
+	switch idx.distance {
+	case Euclidean:
+		sum := 0.0
+		for i := range a {
+			d := a[i] - b[i]
+			sum += d * d
+		}
+		return math.Sqrt(sum)
+	default: // Cosine, expressed as a distance (1 - similarity) so "smaller is closer"
+		var dot, normA, normB float64
+		for i := range a {
+			dot += a[i] * b[i]
+			normA += a[i] * a[i]
+			normB += b[i] * b[i]
+		}
+		denom := math.Sqrt(normA) * math.Sqrt(normB)
+		if denom == 0 {
+			return 1
+		}
+		return 1 - dot/denom
+	}
+}
+
+func (idx *Index) randomLevel() int {
+	return int(math.Floor(-math.Log(rand.Float64()) * idx.mL))
+}
+
+// candidate pairs a node id with its distance to the current query vector.
+type candidate struct {
+	id   string
+	dist float64
+}
+
+// minCandidateHeap is a container/heap min-heap ordered by ascending
+// distance, used as searchLayer's best-first exploration frontier.
+type minCandidateHeap []candidate
+
+func (h minCandidateHeap) Len() int            { return len(h) }
+func (h minCandidateHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h minCandidateHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minCandidateHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *minCandidateHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// maxCandidateHeap is a container/heap max-heap ordered by descending
+// distance, used to hold searchLayer's current best ef results so the
+// single farthest one can be evicted in O(log n) once a closer candidate
+// is found.
+type maxCandidateHeap []candidate
+
+func (h maxCandidateHeap) Len() int            { return len(h) }
+func (h maxCandidateHeap) Less(i, j int) bool  { return h[i].dist > h[j].dist }
+func (h maxCandidateHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxCandidateHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *maxCandidateHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// searchLayer runs a best-first search on a single layer starting from
+// entryPoints, returning up to ef closest candidates to query.
+func (idx *Index) searchLayer(query []float64, entryPoints []string, ef, layer int) []candidate {
+	visited := make(map[string]bool, len(entryPoints))
+	candidates := &minCandidateHeap{}
+	results := &maxCandidateHeap{}
+
+	for _, ep := range entryPoints {
+		n, ok := idx.nodes[ep]
+		if !ok {
+			continue
+		}
+		d := idx.dist(query, n.vector)
+		visited[ep] = true
+		heap.Push(candidates, candidate{ep, d})
+		heap.Push(results, candidate{ep, d})
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate)
+
+		if results.Len() >= ef && c.dist > (*results)[0].dist {
+			break
+		}
+
+		n := idx.nodes[c.id]
+		if layer >= len(n.neighbors) {
+			continue
+		}
+		for _, nbID := range n.neighbors[layer] {
+			if visited[nbID] {
+				continue
+			}
+			visited[nbID] = true
+			nb := idx.nodes[nbID]
+			d := idx.dist(query, nb.vector)
+
+			if results.Len() < ef || d < (*results)[0].dist {
+				heap.Push(candidates, candidate{nbID, d})
+				heap.Push(results, candidate{nbID, d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	out := make([]candidate, len(*results))
+	copy(out, *results)
+	sort.Slice(out, func(i, j int) bool { return out[i].dist < out[j].dist })
+	return out
+}
+
+// selectNeighbors applies the "keep a candidate only if it is closer to the
+// new node than to any already-selected neighbor" pruning heuristic.
+func (idx *Index) selectNeighbors(query []float64, candidates []candidate, m int) []string {
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].dist < candidates[j].dist })
+
+	selected := make([]candidate, 0, m)
+	for _, c := range candidates {
+		if len(selected) >= m {
+			break
+		}
+		n := idx.nodes[c.id]
+		keep := true
+		for _, s := range selected {
+			sNode := idx.nodes[s.id]
+			if idx.dist(n.vector, sNode.vector) < c.dist {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			selected = append(selected, c)
+		}
+	}
+
+	ids := make([]string, len(selected))
+	for i, s := range selected {
+		ids[i] = s.id
+	}
+	return ids
+}
+
+// Insert adds a vector to the graph, running the same search used for
+// queries at efConstruction and pruning neighbor lists with the standard
+// HNSW heuristic.
+func (idx *Index) Insert(id string, vector []float64, metadata map[string]interface{}) error {
+	if len(vector) != idx.dim {
+		return fmt.Errorf("vectorindex: expected %d dimensions, got %d", idx.dim, len(vector))
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	level := idx.randomLevel()
+	n := &node{
+		id:        id,
+		vector:    vector,
+		metadata:  metadata,
+		level:     level,
+		neighbors: make([][]string, level+1),
+	}
+	for l := range n.neighbors {
+		n.neighbors[l] = []string{}
+	}
+
+	if idx.entryPoint == "" {
+		idx.nodes[id] = n
+		idx.entryPoint = id
+		idx.maxLevel = level
+		return nil
+	}
+
+	entry := idx.entryPoint
+	for l := idx.maxLevel; l > level; l-- {
+		nearest := idx.searchLayer(vector, []string{entry}, 1, l)
+		if len(nearest) > 0 {
+			entry = nearest[0].id
+		}
+	}
+
+	// Register n before linking so pruning a neighbor's list can look it up.
+	idx.nodes[id] = n
+
+	entryPoints := []string{entry}
+	for l := min(level, idx.maxLevel); l >= 0; l-- {
+		found := idx.searchLayer(vector, entryPoints, idx.efConstruction, l)
+
+		maxNeighbors := idx.m
+		if l == 0 {
+			maxNeighbors = idx.mMax0
+		}
+		selected := idx.selectNeighbors(vector, found, maxNeighbors)
+		n.neighbors[l] = selected
+
+		for _, nbID := range selected {
+			nb := idx.nodes[nbID]
+			if l >= len(nb.neighbors) {
+				continue
+			}
+			nb.neighbors[l] = append(nb.neighbors[l], id)
+			nbMax := idx.m
+			if l == 0 {
+				nbMax = idx.mMax0
+			}
+			if len(nb.neighbors[l]) > nbMax {
+				cands := make([]candidate, 0, len(nb.neighbors[l]))
+				for _, otherID := range nb.neighbors[l] {
+					other := idx.nodes[otherID]
+					cands = append(cands, candidate{otherID, idx.dist(nb.vector, other.vector)})
+				}
+				nb.neighbors[l] = idx.selectNeighbors(nb.vector, cands, nbMax)
+			}
+		}
+
+		entryPoints = make([]string, len(found))
+		for i, c := range found {
+			entryPoints[i] = c.id
+		}
+	}
+
+	if level > idx.maxLevel {
+		idx.maxLevel = level
+		idx.entryPoint = id
+	}
+	return nil
+}
+
+// Search returns the limit closest vectors to query.
+func (idx *Index) Search(query []float64, limit int) ([]SearchResult, error) {
+	return idx.SearchWithFilter(query, limit, nil)
+}
+
+// SearchWithFilter returns up to limit vectors closest to query whose
+// metadata satisfies filter, enabling hybrid metadata+vector queries. A nil
+// filter matches everything. The filter is applied to the max(efSearch,
+// limit) nearest candidates the search finds, so fewer than limit results
+// are returned when fewer of those candidates match.
+func (idx *Index) SearchWithFilter(query []float64, limit int, filter Filter) ([]SearchResult, error) {
+	if len(query) != idx.dim {
+		return nil, fmt.Errorf("vectorindex: expected %d dimensions, got %d", idx.dim, len(query))
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if idx.entryPoint == "" {
+		return []SearchResult{}, nil
+	}
+
+	entry := idx.entryPoint
+	for l := idx.maxLevel; l > 0; l-- {
+		nearest := idx.searchLayer(query, []string{entry}, 1, l)
+		if len(nearest) > 0 {
+			entry = nearest[0].id
+		}
+	}
+
+	ef := idx.efSearch
+	if limit > ef {
+		ef = limit
+	}
+	found := idx.searchLayer(query, []string{entry}, ef, 0)
+
+	results := make([]SearchResult, 0, limit)
+	for _, c := range found {
+		n := idx.nodes[c.id]
+		if filter != nil && !filter(n.metadata) {
+			continue
+		}
+		score := 1 - c.dist
+		if idx.distance == Euclidean {
+			score = 1 / (1 + c.dist)
+		}
+		results = append(results, SearchResult{ID: n.id, Score: score, Metadata: n.metadata})
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// Save snapshots the graph and vectors to path as a binary-encoded header
+// followed by one variable-length record per node (id, vector, metadata,
+// and per-layer neighbor lists). The layout is not fixed-stride, so it
+// must be read back sequentially with Load rather than mmapped directly;
+// an mmap-friendly format would need a separate offset/index table.
+func (idx *Index) Save(path string) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	defer w.Flush()
+
+	if _, err := w.WriteString(magic); err != nil {
+		return err
+	}
+	header := []int32{int32(idx.dim), int32(idx.distance), int32(idx.m), int32(idx.efConstruction), int32(idx.efSearch), int32(len(idx.nodes)), int32(idx.maxLevel)}
+	for _, v := range header {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	if err := writeString(w, idx.entryPoint); err != nil {
+		return err
+	}
+
+	for id, n := range idx.nodes {
+		if err := writeString(w, id); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, int32(n.level)); err != nil {
+			return err
+		}
+		for _, v := range n.vector {
+			if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+				return err
+			}
+		}
+		metaBytes, err := encodeMetadata(n.metadata)
+		if err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, int32(len(metaBytes))); err != nil {
+			return err
+		}
+		if _, err := w.Write(metaBytes); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, int32(len(n.neighbors))); err != nil {
+			return err
+		}
+		for _, layer := range n.neighbors {
+			if err := binary.Write(w, binary.LittleEndian, int32(len(layer))); err != nil {
+				return err
+			}
+			for _, nbID := range layer {
+				if err := writeString(w, nbID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Load rebuilds an index previously written by Save.
+func Load(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	gotMagic := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, gotMagic); err != nil {
+		return nil, err
+	}
+	if string(gotMagic) != magic {
+		return nil, fmt.Errorf("vectorindex: %s is not a valid index snapshot", path)
+	}
+
+	var dim, distance, m, efConstruction, efSearch, count, maxLevel int32
+	for _, v := range []*int32{&dim, &distance, &m, &efConstruction, &efSearch, &count, &maxLevel} {
+		if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+			return nil, err
+		}
+	}
+	entryPoint, err := readString(r)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := New(int(dim), Distance(distance), WithM(int(m)), WithEfConstruction(int(efConstruction)), WithEfSearch(int(efSearch)))
+	idx.entryPoint = entryPoint
+	idx.maxLevel = int(maxLevel)
+
+	for i := int32(0); i < count; i++ {
+		id, err := readString(r)
+		if err != nil {
+			return nil, err
+		}
+		var level int32
+		if err := binary.Read(r, binary.LittleEndian, &level); err != nil {
+			return nil, err
+		}
+		vector := make([]float64, dim)
+		for j := range vector {
+			if err := binary.Read(r, binary.LittleEndian, &vector[j]); err != nil {
+				return nil, err
+			}
+		}
+		var metaLen int32
+		if err := binary.Read(r, binary.LittleEndian, &metaLen); err != nil {
+			return nil, err
+		}
+		metaBytes := make([]byte, metaLen)
+		if _, err := io.ReadFull(r, metaBytes); err != nil {
+			return nil, err
+		}
+		metadata, err := decodeMetadata(metaBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var layerCount int32
+		if err := binary.Read(r, binary.LittleEndian, &layerCount); err != nil {
+			return nil, err
+		}
+		neighbors := make([][]string, layerCount)
+		for l := range neighbors {
+			var nbCount int32
+			if err := binary.Read(r, binary.LittleEndian, &nbCount); err != nil {
+				return nil, err
+			}
+			layer := make([]string, nbCount)
+			for k := range layer {
+				nbID, err := readString(r)
+				if err != nil {
+					return nil, err
+				}
+				layer[k] = nbID
+			}
+			neighbors[l] = layer
+		}
+
+		idx.nodes[id] = &node{id: id, vector: vector, metadata: metadata, level: int(level), neighbors: neighbors}
+	}
+
+	return idx, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
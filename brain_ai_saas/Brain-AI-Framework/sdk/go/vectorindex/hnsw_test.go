@@ -0,0 +1,152 @@
+package vectorindex
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestInsertAndSearchAcrossMultipleNodes(t *testing.T) {
+	idx := New(2, Euclidean)
+
+	points := map[string][]float64{
+		"origin":    {0, 0},
+		"near":      {1, 0},
+		"far":       {10, 10},
+		"farther":   {20, 20},
+		"southwest": {-5, -5},
+	}
+	for id, vec := range points {
+		if err := idx.Insert(id, vec, map[string]interface{}{"id": id}); err != nil {
+			t.Fatalf("Insert(%q) returned an error: %v", id, err)
+		}
+	}
+
+	if got := idx.Len(); got != len(points) {
+		t.Fatalf("expected Len() %d, got %d", len(points), got)
+	}
+
+	results, err := idx.Search([]float64{0, 0}, 2)
+	if err != nil {
+		t.Fatalf("Search returned an error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].ID != "origin" {
+		t.Fatalf("expected the closest result to be %q, got %q", "origin", results[0].ID)
+	}
+	if results[1].ID != "near" {
+		t.Fatalf("expected the second-closest result to be %q, got %q", "near", results[1].ID)
+	}
+}
+
+func TestSearchWithFilterSkipsNonMatchingMetadata(t *testing.T) {
+	idx := New(2, Euclidean)
+	idx.Insert("a", []float64{0, 0}, map[string]interface{}{"category": "x"})
+	idx.Insert("b", []float64{1, 0}, map[string]interface{}{"category": "y"})
+	idx.Insert("c", []float64{2, 0}, map[string]interface{}{"category": "x"})
+
+	onlyX := func(metadata map[string]interface{}) bool {
+		return metadata["category"] == "x"
+	}
+
+	results, err := idx.SearchWithFilter([]float64{0, 0}, 2, onlyX)
+	if err != nil {
+		t.Fatalf("SearchWithFilter returned an error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.ID == "b" {
+			t.Fatal("expected the filter to exclude id \"b\"")
+		}
+	}
+}
+
+func TestSelectNeighborsPrunesFartherCandidates(t *testing.T) {
+	idx := New(1, Euclidean, WithM(4))
+
+	// Three candidates along a line from the query at 0: at 1, 2, and 10.
+	// "close" (at 1) is selected first. "mid" (at 2) is 1 from "close" but
+	// 2 from the query, and "far" (at 10) is 9 from "close" but 10 from the
+	// query, so the heuristic, which keeps a candidate only if no selected
+	// neighbor is closer to it than the query is, prunes both.
+	idx.nodes["close"] = &node{id: "close", vector: []float64{1}}
+	idx.nodes["mid"] = &node{id: "mid", vector: []float64{2}}
+	idx.nodes["far"] = &node{id: "far", vector: []float64{10}}
+
+	candidates := []candidate{
+		{id: "close", dist: 1},
+		{id: "mid", dist: 2},
+		{id: "far", dist: 10},
+	}
+
+	selected := idx.selectNeighbors([]float64{0}, candidates, 4)
+
+	if len(selected) == 0 || selected[0] != "close" {
+		t.Fatalf("expected the closest candidate to be selected first, got %v", selected)
+	}
+	for _, id := range selected {
+		if id == "far" {
+			t.Fatalf("expected \"far\" to be pruned since it is farther from the query than \"close\" is from \"far\": %v", selected)
+		}
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	idx := New(2, Cosine, WithM(4), WithEfConstruction(32), WithEfSearch(16))
+	points := map[string][]float64{
+		"a": {1, 0},
+		"b": {0, 1},
+		"c": {0.9, 0.1},
+		"d": {-1, 0},
+	}
+	for id, vec := range points {
+		if err := idx.Insert(id, vec, map[string]interface{}{"label": id}); err != nil {
+			t.Fatalf("Insert(%q) returned an error: %v", id, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "index.snapshot")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save returned an error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+
+	if got := loaded.Len(); got != len(points) {
+		t.Fatalf("expected the loaded index to have %d nodes, got %d", len(points), got)
+	}
+
+	want, err := idx.Search([]float64{1, 0}, 2)
+	if err != nil {
+		t.Fatalf("Search on the original index returned an error: %v", err)
+	}
+	got, err := loaded.Search([]float64{1, 0}, 2)
+	if err != nil {
+		t.Fatalf("Search on the loaded index returned an error: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d results from the loaded index, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			t.Fatalf("result %d: expected id %q, got %q", i, want[i].ID, got[i].ID)
+		}
+		if got[i].Metadata["label"] != want[i].Metadata["label"] {
+			t.Fatalf("result %d: expected metadata label %v, got %v", i, want[i].Metadata["label"], got[i].Metadata["label"])
+		}
+	}
+}
+
+func TestInsertRejectsWrongDimensions(t *testing.T) {
+	idx := New(3, Euclidean)
+	if err := idx.Insert("bad", []float64{1, 2}, nil); err == nil {
+		t.Fatal("expected Insert to reject a vector with the wrong dimensionality")
+	}
+}